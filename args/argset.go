@@ -0,0 +1,481 @@
+// Package args implements positional argument parsing for cmdy Commands,
+// in the same spirit as the standard library's flag package but for the
+// arguments that remain after flags have been parsed.
+package args
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Value is implemented by anything that can back a positional argument,
+// mirroring the standard library's flag.Value.
+//
+// If a Value also implements Hinter, ArgSet.Usage() uses the returned
+// kind/hint to annotate the argument's placeholder.
+type Value interface {
+	String() string
+	Set(string) error
+}
+
+// Hinter may be implemented by a Value to control how it is rendered in
+// ArgSet.Usage(): kind is shown as "(kind)" and hint is appended after
+// it.
+type Hinter interface {
+	Hint() (kind, hint string)
+}
+
+// Secret may be implemented by a Value to indicate that, when prompted
+// for interactively, its input should be masked rather than echoed.
+type Secret interface {
+	Secret() bool
+}
+
+// Candidate is one shell completion suggestion offered for a Value (or
+// a FlagSet value - cmdy.Candidate is an alias of this type so the two
+// packages share a single completion contract).
+type Candidate struct {
+	Value       string
+	Description string
+}
+
+// Completer may be implemented by a Value to drive shell completion.
+// Complete is called with the partial token being completed and the
+// values already parsed for whatever precedes it, and returns matching
+// candidates.
+type Completer interface {
+	Complete(prefix string, args []string) []Candidate
+}
+
+// ParseOptions configures the optional interactive behaviour of
+// ArgSet.ParseContext.
+type ParseOptions struct {
+	// Interactive, when true, prompts on Stderr for any required
+	// positional missing from argv instead of returning an error for
+	// it. Callers are expected to only set this when Stdin is attached
+	// to a terminal, so scripts with piped input never hang.
+	Interactive bool
+
+	Stdin  io.Reader
+	Stderr io.Writer
+}
+
+// Count constrains how many values a Remaining argument will accept.
+type Count struct {
+	min, max int
+}
+
+// AnyLen accepts any number of remaining values, including none.
+var AnyLen = Count{min: 0, max: -1}
+
+// Min requires at least n remaining values.
+func Min(n int) Count { return Count{min: n, max: -1} }
+
+// Max allows at most n remaining values.
+func Max(n int) Count { return Count{min: 0, max: n} }
+
+type argEntry struct {
+	name     string
+	usage    string
+	value    Value
+	optional bool
+}
+
+type remainingEntry struct {
+	name  string
+	usage string
+	count Count
+	set   func(items []string) error
+}
+
+// ArgSet collects a Command's positional arguments, in the order they
+// should appear on the command line. Required arguments must generally
+// be declared before optional ones; a Remaining argument, if any, must
+// be declared last.
+type ArgSet struct {
+	entries   []*argEntry
+	remaining *remainingEntry
+}
+
+// NewArgSet builds an empty ArgSet.
+func NewArgSet() *ArgSet {
+	return &ArgSet{}
+}
+
+// Var registers a required positional argument backed by a custom
+// Value.
+func (as *ArgSet) Var(value Value, name, usage string) {
+	as.entries = append(as.entries, &argEntry{name: name, usage: usage, value: value})
+}
+
+// String registers a required positional string argument.
+func (as *ArgSet) String(p *string, name, usage string) {
+	as.entries = append(as.entries, &argEntry{name: name, usage: usage, value: (*stringValue)(p)})
+}
+
+// StringOptional registers an optional positional string argument,
+// populated with def if it is not supplied.
+func (as *ArgSet) StringOptional(p *string, name, def, usage string) {
+	*p = def
+	as.entries = append(as.entries, &argEntry{name: name, usage: usage, value: (*stringValue)(p), optional: true})
+}
+
+// Remaining registers a variadic tail argument collecting every
+// remaining string, bounded by count. At most one Remaining argument may
+// be registered, and it must be the last one declared.
+func (as *ArgSet) Remaining(p *[]string, name string, count Count, usage string) {
+	as.remaining = &remainingEntry{
+		name: name, usage: usage, count: count,
+		set: func(items []string) error {
+			*p = append([]string(nil), items...)
+			return nil
+		},
+	}
+}
+
+// RemainingInts is like Remaining, but parses each value as an int.
+func (as *ArgSet) RemainingInts(p *[]int, name string, count Count, usage string) {
+	as.remaining = &remainingEntry{
+		name: name, usage: usage, count: count,
+		set: func(items []string) error {
+			out := make([]int, len(items))
+			for i, s := range items {
+				n, err := strconv.Atoi(s)
+				if err != nil {
+					return fmt.Errorf("arg <%s>: invalid int %q", name, s)
+				}
+				out[i] = n
+			}
+			*p = out
+			return nil
+		},
+	}
+}
+
+// Parse assigns args to the registered arguments, in declaration order.
+//
+// Required arguments that precede every optional argument must be
+// supplied or Parse returns an error; arguments after the first optional
+// one are filled on a best-effort basis from whatever is left over. Any
+// Remaining argument consumes everything after the last simple argument,
+// subject to its Count bounds. Tokens left over after that is an error.
+func (as *ArgSet) Parse(argv []string) error {
+	return as.ParseContext(argv, ParseOptions{})
+}
+
+// ParseContext is Parse with optional interactive prompting: see
+// ParseOptions.Interactive.
+func (as *ArgSet) ParseContext(argv []string, opts ParseOptions) error {
+	leading := 0
+	for _, e := range as.entries {
+		if e.optional {
+			break
+		}
+		leading++
+	}
+
+	n := len(argv)
+	if n < leading {
+		if !opts.Interactive {
+			return fmt.Errorf("missing arg <%s> at position %d", as.entries[n].name, n+1)
+		}
+		for _, e := range as.entries[n:leading] {
+			if err := promptEntry(e, opts.Stdin, opts.Stderr); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Any optional argument not supplied in argv is also offered to the
+	// interactive prompt, showing its default rather than requiring one.
+	if opts.Interactive {
+		start := leading
+		if n > start {
+			start = n
+		}
+		if start > len(as.entries) {
+			start = len(as.entries)
+		}
+		for _, e := range as.entries[start:] {
+			if err := promptEntry(e, opts.Stdin, opts.Stderr); err != nil {
+				return err
+			}
+		}
+	}
+
+	idx := 0
+	for _, e := range as.entries {
+		if idx >= n {
+			break
+		}
+		if err := e.value.Set(argv[idx]); err != nil {
+			return fmt.Errorf("arg <%s>: %w", e.name, err)
+		}
+		idx++
+	}
+
+	if as.remaining != nil {
+		items := argv[idx:]
+		idx = n
+
+		if len(items) < as.remaining.count.min {
+			return fmt.Errorf("arg <%s> requires at least %d value(s)", as.remaining.name, as.remaining.count.min)
+		}
+		if max := as.remaining.count.max; max >= 0 && len(items) > max {
+			return fmt.Errorf("arg <%s> accepts at most %d value(s)", as.remaining.name, max)
+		}
+		if err := as.remaining.set(items); err != nil {
+			return err
+		}
+	}
+
+	if idx < n {
+		extra := n - idx
+		word := "arg"
+		if extra != 1 {
+			word = "args"
+		}
+		return fmt.Errorf("found %d additional %s", extra, word)
+	}
+
+	return nil
+}
+
+// Complete returns shell completion candidates for the positional
+// argument at the position immediately following typed, given the
+// partial value (prefix) being completed there. It looks up which
+// entry that position belongs to and defers to its Value's Complete
+// method, returning nil if that Value doesn't implement Completer.
+//
+// Candidates for a Remaining argument aren't supported, since it isn't
+// backed by a single Value.
+func (as *ArgSet) Complete(prefix string, typed []string) []Candidate {
+	idx := len(typed)
+	if idx >= len(as.entries) {
+		return nil
+	}
+	c, ok := as.entries[idx].value.(Completer)
+	if !ok {
+		return nil
+	}
+	return c.Complete(prefix, typed)
+}
+
+// Synopsis renders a short inline placeholder for the whole ArgSet, e.g.
+// "<foo> [bar] [baz...]", suitable for a "Usage: prog [options] ..."
+// line.
+func (as *ArgSet) Synopsis() string {
+	var parts []string
+	for _, e := range as.entries {
+		if e.optional {
+			parts = append(parts, "["+e.name+"]")
+		} else {
+			parts = append(parts, "<"+e.name+">")
+		}
+	}
+	if r := as.remaining; r != nil {
+		if r.count.min > 0 {
+			parts = append(parts, "<"+r.name+"...>")
+		} else {
+			parts = append(parts, "["+r.name+"...]")
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// Usage renders one block per registered argument: a "<name>" header
+// (annotated with kind/hint when the backing Value implements Hinter)
+// followed by its indented usage text.
+func (as *ArgSet) Usage() string {
+	var b strings.Builder
+	for _, e := range as.entries {
+		b.WriteString(renderArgLine(e.name, e.usage, e.value))
+	}
+	if r := as.remaining; r != nil {
+		b.WriteString(renderArgLine(r.name+"...", r.usage, nil))
+	}
+	return b.String()
+}
+
+func renderArgLine(name, usage string, value Value) string {
+	header := "  <" + name + ">"
+	if h, ok := value.(Hinter); ok {
+		kind, hint := h.Hint()
+		if kind != "" {
+			header += " (" + kind + ")"
+		}
+		if hint != "" {
+			header += " " + hint
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(header)
+	b.WriteString("\n")
+	if usage != "" {
+		b.WriteString("        " + usage + "\n")
+	}
+	return b.String()
+}
+
+type stringValue string
+
+func (s *stringValue) String() string     { return string(*s) }
+func (s *stringValue) Set(v string) error { *s = stringValue(v); return nil }
+
+// EnumVar is a Value restricted to a fixed set of allowed strings. It
+// implements Hinter (listing the options) and Completer (offering
+// every option matching the prefix being completed), and can back
+// either a positional argument (via ArgSet.Var) or a flag (via
+// FlagSet.Var, since the two share the same String/Set shape).
+type EnumVar struct {
+	Options []string
+	value   string
+}
+
+// NewEnumVar builds an EnumVar defaulting to def, restricted to
+// options.
+func NewEnumVar(def string, options ...string) *EnumVar {
+	return &EnumVar{Options: options, value: def}
+}
+
+func (e *EnumVar) String() string { return e.value }
+
+func (e *EnumVar) Set(s string) error {
+	for _, o := range e.Options {
+		if o == s {
+			e.value = s
+			return nil
+		}
+	}
+	return fmt.Errorf("must be one of: %s", strings.Join(e.Options, ", "))
+}
+
+func (e *EnumVar) Hint() (kind, hint string) {
+	return "enum", "(one of: " + strings.Join(e.Options, ", ") + ")"
+}
+
+func (e *EnumVar) Complete(prefix string, args []string) []Candidate {
+	var out []Candidate
+	for _, o := range e.Options {
+		if strings.HasPrefix(o, prefix) {
+			out = append(out, Candidate{Value: o})
+		}
+	}
+	return out
+}
+
+// PathVar is a Value backed by a filesystem path. It implements
+// Completer, offering the matching directory entries for whatever
+// prefix is being completed, with directories suffixed by a path
+// separator so a shell can keep descending into them.
+type PathVar struct {
+	value string
+}
+
+// NewPathVar builds a PathVar defaulting to def.
+func NewPathVar(def string) *PathVar {
+	return &PathVar{value: def}
+}
+
+func (p *PathVar) String() string     { return p.value }
+func (p *PathVar) Set(s string) error { p.value = s; return nil }
+
+func (p *PathVar) Complete(prefix string, args []string) []Candidate {
+	matches, err := filepath.Glob(prefix + "*")
+	if err != nil {
+		return nil
+	}
+	out := make([]Candidate, 0, len(matches))
+	for _, m := range matches {
+		if info, err := os.Stat(m); err == nil && info.IsDir() {
+			m += string(filepath.Separator)
+		}
+		out = append(out, Candidate{Value: m})
+	}
+	return out
+}
+
+// promptEntry asks stderr for a value for e, re-prompting on invalid
+// input until Set succeeds. If e is optional, its current (default)
+// value is shown in the prompt and an empty line leaves it untouched
+// instead of being treated as missing input.
+func promptEntry(e *argEntry, stdin io.Reader, stderr io.Writer) error {
+	label := "<" + e.name + ">"
+	if h, ok := e.value.(Hinter); ok {
+		if kind, hint := h.Hint(); kind != "" || hint != "" {
+			label += " "
+			if kind != "" {
+				label += "(" + kind + ") "
+			}
+			label += hint
+		}
+	}
+	if e.optional {
+		label += " (default: " + e.value.String() + ")"
+	}
+
+	reader := bufio.NewReader(stdin)
+	secret, masked := e.value.(Secret)
+
+	for {
+		if e.usage != "" {
+			fmt.Fprintf(stderr, "%s: %s\n", label, e.usage)
+		}
+		fmt.Fprintf(stderr, "%s: ", e.name)
+
+		restore := func() {}
+		if masked && secret.Secret() {
+			restore = muteEcho(stdin)
+		}
+		line, err := reader.ReadString('\n')
+		restore()
+		if masked && secret.Secret() {
+			fmt.Fprintln(stderr)
+		}
+		if err != nil && line == "" {
+			if e.optional {
+				return nil
+			}
+			return fmt.Errorf("arg <%s>: %w", e.name, err)
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" && e.optional {
+			return nil
+		}
+		if err := e.value.Set(line); err != nil {
+			fmt.Fprintf(stderr, "invalid value for <%s>: %s\n", e.name, err)
+			continue
+		}
+		return nil
+	}
+}
+
+// muteEcho makes a best-effort attempt to disable terminal echo on
+// stdin for the duration of a masked prompt, using `stty` rather than
+// pulling in a terminal-handling dependency. It is a no-op (callers
+// simply see unmasked input) on platforms without `stty` or when stdin
+// isn't a real terminal.
+func muteEcho(stdin io.Reader) func() {
+	f, ok := stdin.(interface{ Fd() uintptr })
+	if !ok {
+		return func() {}
+	}
+
+	_ = f // stdin exposes a file descriptor; stty fails harmlessly if it isn't a terminal
+	set := func(mode string) {
+		cmd := exec.Command("stty", mode)
+		cmd.Stdin = stdin
+		_ = cmd.Run()
+	}
+
+	set("-echo")
+	return func() { set("echo") }
+}
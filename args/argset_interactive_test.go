@@ -0,0 +1,129 @@
+package args
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/shabbyrobe/golib/assert"
+)
+
+func TestParseContextPromptsForMissingRequired(t *testing.T) {
+	tt := assert.WrapTB(t)
+
+	var foo string
+	as := NewArgSet()
+	as.String(&foo, "foo", "the foo to use")
+
+	stdin := strings.NewReader("hello\n")
+	var stderr bytes.Buffer
+
+	tt.MustOK(as.ParseContext(nil, ParseOptions{Interactive: true, Stdin: stdin, Stderr: &stderr}))
+	tt.MustEqual("hello", foo)
+	tt.MustAssert(strings.Contains(stderr.String(), "foo"), stderr.String())
+}
+
+type validatedValue struct {
+	val string
+}
+
+func (v *validatedValue) String() string { return v.val }
+func (v *validatedValue) Set(s string) error {
+	if s != "valid" {
+		return errInvalid
+	}
+	v.val = s
+	return nil
+}
+
+var errInvalid = errInvalidValue{}
+
+type errInvalidValue struct{}
+
+func (errInvalidValue) Error() string { return "not valid" }
+
+func TestParseContextRepromptsOnInvalidValue(t *testing.T) {
+	tt := assert.WrapTB(t)
+
+	var v validatedValue
+	as := NewArgSet()
+	as.Var(&v, "foo", "usage")
+
+	stdin := strings.NewReader("nope\nvalid\n")
+	var stderr bytes.Buffer
+
+	tt.MustOK(as.ParseContext(nil, ParseOptions{Interactive: true, Stdin: stdin, Stderr: &stderr}))
+	tt.MustEqual("valid", v.val)
+	tt.MustAssert(strings.Contains(stderr.String(), "invalid value"), stderr.String())
+}
+
+func TestParseContextNonInteractiveStillErrors(t *testing.T) {
+	tt := assert.WrapTB(t)
+
+	var foo string
+	as := NewArgSet()
+	as.String(&foo, "foo", "usage")
+
+	err := as.ParseContext(nil, ParseOptions{})
+	tt.MustAssert(err != nil && strings.Contains(err.Error(), "missing arg <foo>"), err)
+}
+
+func TestParseContextPromptsForOptionalShowingDefault(t *testing.T) {
+	tt := assert.WrapTB(t)
+
+	var foo string
+	as := NewArgSet()
+	as.StringOptional(&foo, "foo", "fallback", "usage")
+
+	stdin := strings.NewReader("override\n")
+	var stderr bytes.Buffer
+
+	tt.MustOK(as.ParseContext(nil, ParseOptions{Interactive: true, Stdin: stdin, Stderr: &stderr}))
+	tt.MustEqual("override", foo)
+	tt.MustAssert(strings.Contains(stderr.String(), "default: fallback"), stderr.String())
+}
+
+func TestParseContextOptionalEmptyLineKeepsDefault(t *testing.T) {
+	tt := assert.WrapTB(t)
+
+	var foo string
+	as := NewArgSet()
+	as.StringOptional(&foo, "foo", "fallback", "usage")
+
+	stdin := strings.NewReader("\n")
+	var stderr bytes.Buffer
+
+	tt.MustOK(as.ParseContext(nil, ParseOptions{Interactive: true, Stdin: stdin, Stderr: &stderr}))
+	tt.MustEqual("fallback", foo)
+}
+
+// TestParseContextInteractiveRemainingOnlyDoesNotPanic covers the ArgSet
+// shape a Group uses for its own dispatch args: no simple entries, just a
+// Remaining sink. Interactive mode must not try to slice past the (empty)
+// entries slice.
+func TestParseContextInteractiveRemainingOnlyDoesNotPanic(t *testing.T) {
+	tt := assert.WrapTB(t)
+
+	var rest []string
+	as := NewArgSet()
+	as.Remaining(&rest, "args", AnyLen, "usage")
+
+	var stderr bytes.Buffer
+	tt.MustOK(as.ParseContext([]string{"sub"}, ParseOptions{Interactive: true, Stdin: strings.NewReader(""), Stderr: &stderr}))
+	tt.MustEqual([]string{"sub"}, rest)
+}
+
+// TestParseContextInteractiveExcessArgsDoesNotPanic covers argv longer
+// than the declared entries with no Remaining sink to absorb the extras:
+// the excess-argument error must fire instead of a slice-bounds panic.
+func TestParseContextInteractiveExcessArgsDoesNotPanic(t *testing.T) {
+	tt := assert.WrapTB(t)
+
+	var foo string
+	as := NewArgSet()
+	as.String(&foo, "foo", "usage")
+
+	var stderr bytes.Buffer
+	err := as.ParseContext([]string{"a", "b", "c"}, ParseOptions{Interactive: true, Stdin: strings.NewReader(""), Stderr: &stderr})
+	tt.MustAssert(err != nil && strings.Contains(err.Error(), "found 2 additional args"), err)
+}
@@ -0,0 +1,51 @@
+// Command cmdy-mkman renders troff man pages for a cmdy.Command tree
+// using the manpage package.
+//
+// It is a thin wrapper around manpage.Generate: copy this file into your
+// own module, point root at your application's top-level cmdy.Builder,
+// and build or `go run` it as part of your release process.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ArtProcessors/cmdy"
+	"github.com/ArtProcessors/cmdy/manpage"
+)
+
+// root is the command tree to document. Replace this with your own
+// application's top-level cmdy.Builder before building this binary.
+var root cmdy.Builder
+
+func main() {
+	var opts manpage.Options
+
+	outDir := flag.String("out", ".", "directory to write man pages into")
+	name := flag.String("name", "myapp", "program name used in page titles")
+	flag.StringVar(&opts.Section, "section", "1", "man section number")
+	flag.StringVar(&opts.Source, "source", "", "source string shown in the page footer")
+	flag.StringVar(&opts.Manual, "manual", "", "manual name shown in the page header")
+	flag.Parse()
+
+	if root == nil {
+		fmt.Fprintln(os.Stderr, "cmdy-mkman: no root command configured; edit cmd/cmdy-mkman/main.go to set `root`")
+		os.Exit(1)
+	}
+
+	pages, err := manpage.Generate(*name, root, opts)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "cmdy-mkman:", err)
+		os.Exit(1)
+	}
+
+	for page, body := range pages {
+		path := filepath.Join(*outDir, page+"."+opts.Section)
+		if err := os.WriteFile(path, body, 0o644); err != nil {
+			fmt.Fprintln(os.Stderr, "cmdy-mkman:", err)
+			os.Exit(1)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package cmdy
+
+import (
+	arg "github.com/ArtProcessors/cmdy/args"
+)
+
+// Command is implemented by anything that can be run by a Runner.
+//
+// Flags() and Args() are called first so the Runner can pass them to
+// Configure(), which registers whatever options and positional
+// arguments the command accepts. Either may return nil if the command
+// takes none; Runner substitutes an empty set in that case.
+type Command interface {
+	Synopsis() string
+	Usage() string
+	Flags() *FlagSet
+	Args() *arg.ArgSet
+	Configure(flags *FlagSet, args *arg.ArgSet)
+	Run(c Context) error
+}
+
+// Builder constructs a fresh Command instance. Runner calls Builder once
+// per invocation so Commands may hold mutable state without leaking
+// between runs.
+type Builder func() Command
@@ -4,8 +4,8 @@ import (
 	"context"
 	"testing"
 
-	"github.com/shabbyrobe/cmdy/arg"
-	"github.com/shabbyrobe/cmdy/internal/assert"
+	arg "github.com/ArtProcessors/cmdy/args"
+	"github.com/shabbyrobe/golib/assert"
 )
 
 func TestCommand_FlagsArgs(t *testing.T) {
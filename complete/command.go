@@ -0,0 +1,55 @@
+package complete
+
+import (
+	"fmt"
+
+	"github.com/ArtProcessors/cmdy"
+	arg "github.com/ArtProcessors/cmdy/args"
+)
+
+// Command builds the hidden "__complete" subcommand a shell's
+// completion function shells out to. root is the builder for the top
+// of the command tree being completed (usually the same Builder passed
+// to cmdy.Run).
+//
+// Register it on a Group with AddHidden so it's dispatched like any
+// other subcommand but left out of -help, man pages, and its own
+// completion candidates:
+//
+//	group.AddHidden("__complete", complete.Command(root))
+func Command(root cmdy.Builder) cmdy.Builder {
+	return func() cmdy.Command { return &completeCommand{root: root} }
+}
+
+type completeCommand struct {
+	root  cmdy.Builder
+	words []string
+}
+
+func (c *completeCommand) Synopsis() string { return "Print shell completion candidates" }
+
+func (c *completeCommand) Usage() string {
+	return "Not intended to be invoked directly; a shell's completion function calls this " +
+		"with the command line typed so far and prints one candidate per line."
+}
+
+func (c *completeCommand) Flags() *cmdy.FlagSet { return nil }
+
+func (c *completeCommand) Args() *arg.ArgSet {
+	as := arg.NewArgSet()
+	as.Remaining(&c.words, "words", arg.AnyLen, "the command line typed so far, including the partial word being completed")
+	return as
+}
+
+func (c *completeCommand) Configure(flags *cmdy.FlagSet, args *arg.ArgSet) {}
+
+func (c *completeCommand) Run(ctx cmdy.Context) error {
+	for _, cand := range Candidates(c.root, c.words) {
+		if cand.Description != "" {
+			fmt.Fprintf(ctx.Stdout, "%s\t%s\n", cand.Value, cand.Description)
+		} else {
+			fmt.Fprintln(ctx.Stdout, cand.Value)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+// Package complete implements shell completion for cmdy command trees,
+// driven by the same Command/FlagSet/arg.ArgSet metadata that backs
+// -help output and cmdy/manpage.
+//
+// Candidates walks the command line typed so far (descending into
+// Group subcommands, flag names, and any Completer-implementing flag
+// or positional value) and returns the matching completions. Command
+// builds a hidden subcommand that renders them for a shell to consume;
+// wire it up with group.AddHidden("__complete", complete.Command(root)).
+// cmdy/complete/install writes the shell snippet that calls it.
+package complete
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ArtProcessors/cmdy"
+	arg "github.com/ArtProcessors/cmdy/args"
+)
+
+// commandGroup is satisfied by cmdy.Group; see the same interface in
+// cmdy/manpage.
+type commandGroup interface {
+	Subcommands() map[string]cmdy.Builder
+}
+
+// Candidates returns shell completion candidates for the word being
+// typed, given the command line typed so far: words holds every
+// preceding word plus the (possibly empty) partial word being
+// completed, in that order, not including the program name itself.
+func Candidates(builder cmdy.Builder, words []string) []arg.Candidate {
+	if len(words) == 0 {
+		words = []string{""}
+	}
+
+	cmd, flags, args := build(builder)
+
+	for len(words) > 1 {
+		grp, ok := cmd.(commandGroup)
+		if !ok {
+			break
+		}
+		next, ok := grp.Subcommands()[words[0]]
+		if !ok {
+			break
+		}
+		cmd, flags, args = build(next)
+		words = words[1:]
+	}
+
+	prefix := words[len(words)-1]
+	preceding := words[:len(words)-1]
+
+	if strings.HasPrefix(prefix, "-") {
+		return flagCandidates(flags, prefix, preceding)
+	}
+
+	var out []arg.Candidate
+	if grp, ok := cmd.(commandGroup); ok {
+		out = append(out, subcommandCandidates(grp, prefix)...)
+	}
+	out = append(out, args.Complete(prefix, preceding)...)
+	return out
+}
+
+func build(b cmdy.Builder) (cmdy.Command, *cmdy.FlagSet, *arg.ArgSet) {
+	cmd := b()
+
+	flags := cmd.Flags()
+	if flags == nil {
+		flags = cmdy.NewFlagSet()
+	}
+	args := cmd.Args()
+	if args == nil {
+		args = arg.NewArgSet()
+	}
+	cmd.Configure(flags, args)
+	return cmd, flags, args
+}
+
+func flagCandidates(flags *cmdy.FlagSet, prefix string, preceding []string) []arg.Candidate {
+	if eq := strings.IndexByte(prefix, '='); eq >= 0 {
+		name := strings.TrimLeft(prefix[:eq], "-")
+		val, ok := flags.Value(name)
+		if !ok {
+			return nil
+		}
+		c, ok := val.(cmdy.Completer)
+		if !ok {
+			return nil
+		}
+		out := c.Complete(prefix[eq+1:], preceding)
+		for i := range out {
+			out[i].Value = prefix[:eq+1] + out[i].Value
+		}
+		return out
+	}
+
+	var out []arg.Candidate
+	for _, name := range flags.Names() {
+		full := flags.Prefix(name) + name
+		if strings.HasPrefix(full, prefix) {
+			out = append(out, arg.Candidate{Value: full})
+		}
+	}
+	return out
+}
+
+func subcommandCandidates(grp commandGroup, prefix string) []arg.Candidate {
+	var out []arg.Candidate
+	for _, name := range subcommandNames(grp) {
+		if strings.HasPrefix(name, prefix) {
+			out = append(out, arg.Candidate{Value: name})
+		}
+	}
+	return out
+}
+
+func subcommandNames(grp commandGroup) []string {
+	names := make([]string, 0, len(grp.Subcommands()))
+	for n := range grp.Subcommands() {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,150 @@
+package complete
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ArtProcessors/cmdy"
+	arg "github.com/ArtProcessors/cmdy/args"
+	"github.com/shabbyrobe/golib/assert"
+)
+
+type fakeCmd struct {
+	flags func() *cmdy.FlagSet
+	args  func() *arg.ArgSet
+}
+
+func (c *fakeCmd) Synopsis() string { return "" }
+func (c *fakeCmd) Usage() string    { return "" }
+func (c *fakeCmd) Flags() *cmdy.FlagSet {
+	if c.flags == nil {
+		return nil
+	}
+	return c.flags()
+}
+func (c *fakeCmd) Args() *arg.ArgSet {
+	if c.args == nil {
+		return nil
+	}
+	return c.args()
+}
+func (c *fakeCmd) Configure(*cmdy.FlagSet, *arg.ArgSet) {}
+func (c *fakeCmd) Run(cmdy.Context) error               { return nil }
+
+type fakeGroup struct {
+	fakeCmd
+	subs map[string]cmdy.Builder
+}
+
+func (g *fakeGroup) Subcommands() map[string]cmdy.Builder { return g.subs }
+
+func contains(cands []arg.Candidate, value string) bool {
+	for _, c := range cands {
+		if c.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+func TestCandidatesSubcommandNames(t *testing.T) {
+	tt := assert.WrapTB(t)
+
+	g := &fakeGroup{subs: map[string]cmdy.Builder{
+		"start": func() cmdy.Command { return &fakeCmd{} },
+		"stop":  func() cmdy.Command { return &fakeCmd{} },
+	}}
+
+	got := Candidates(func() cmdy.Command { return g }, []string{"st"})
+	tt.MustAssert(contains(got, "start") && contains(got, "stop"), got)
+}
+
+func TestCandidatesDescendsIntoSubcommand(t *testing.T) {
+	tt := assert.WrapTB(t)
+
+	var enum = arg.NewEnumVar("", "alpha", "beta")
+	sub := &fakeCmd{args: func() *arg.ArgSet {
+		as := arg.NewArgSet()
+		as.Var(enum, "mode", "usage")
+		return as
+	}}
+
+	g := &fakeGroup{subs: map[string]cmdy.Builder{
+		"sub": func() cmdy.Command { return sub },
+	}}
+
+	got := Candidates(func() cmdy.Command { return g }, []string{"sub", "a"})
+	tt.MustAssert(contains(got, "alpha") && !contains(got, "beta"), got)
+}
+
+func TestCandidatesFlagNames(t *testing.T) {
+	tt := assert.WrapTB(t)
+
+	cmd := &fakeCmd{flags: func() *cmdy.FlagSet {
+		fs := cmdy.NewFlagSet()
+		var s string
+		fs.StringVar(&s, "foo", "", "usage")
+		fs.StringVar(&s, "bar", "", "usage")
+		return fs
+	}}
+
+	got := Candidates(func() cmdy.Command { return cmd }, []string{"-f"})
+	tt.MustAssert(contains(got, "-foo") && !contains(got, "-bar"), got)
+}
+
+func TestCandidatesFlagValueCompleter(t *testing.T) {
+	tt := assert.WrapTB(t)
+
+	enum := arg.NewEnumVar("", "alpha", "beta")
+	cmd := &fakeCmd{flags: func() *cmdy.FlagSet {
+		fs := cmdy.NewFlagSet()
+		fs.Var(enum, "mode", "usage")
+		return fs
+	}}
+
+	got := Candidates(func() cmdy.Command { return cmd }, []string{"-mode=a"})
+	tt.MustAssert(contains(got, "-mode=alpha"), got)
+}
+
+func TestCandidatesSubcommandNamesAreSorted(t *testing.T) {
+	tt := assert.WrapTB(t)
+
+	g := &fakeGroup{subs: map[string]cmdy.Builder{
+		"zebra": func() cmdy.Command { return &fakeCmd{} },
+		"alpha": func() cmdy.Command { return &fakeCmd{} },
+		"mid":   func() cmdy.Command { return &fakeCmd{} },
+	}}
+
+	got := Candidates(func() cmdy.Command { return g }, []string{""})
+	tt.MustEqual([]string{"alpha", "mid", "zebra"}, candidateValues(got))
+}
+
+func TestCandidatesPathCompleter(t *testing.T) {
+	tt := assert.WrapTB(t)
+
+	dir := t.TempDir()
+	tt.MustOK(os.WriteFile(filepath.Join(dir, "apple.txt"), nil, 0o644))
+	tt.MustOK(os.WriteFile(filepath.Join(dir, "avocado.txt"), nil, 0o644))
+	tt.MustOK(os.WriteFile(filepath.Join(dir, "banana.txt"), nil, 0o644))
+
+	path := arg.NewPathVar("")
+	cmd := &fakeCmd{args: func() *arg.ArgSet {
+		as := arg.NewArgSet()
+		as.Var(path, "file", "usage")
+		return as
+	}}
+
+	got := Candidates(func() cmdy.Command { return cmd }, []string{filepath.Join(dir, "a")})
+	tt.MustAssert(contains(got, filepath.Join(dir, "apple.txt")), got)
+	tt.MustAssert(contains(got, filepath.Join(dir, "avocado.txt")), got)
+	tt.MustAssert(!contains(got, filepath.Join(dir, "banana.txt")), got)
+}
+
+func candidateValues(cands []arg.Candidate) []string {
+	out := make([]string, len(cands))
+	for i, c := range cands {
+		out[i] = c.Value
+	}
+	return out
+}
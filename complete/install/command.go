@@ -0,0 +1,55 @@
+package install
+
+import (
+	"strings"
+
+	"github.com/ArtProcessors/cmdy"
+	arg "github.com/ArtProcessors/cmdy/args"
+)
+
+// Command builds a "complete install" subcommand that prints the
+// snippet for the -shell flag to stdout. The snippet calls
+// "prog __complete", so the hidden __complete subcommand belongs on the
+// program's top-level Group (app below, built by root), e.g.:
+//
+//	app.AddHidden("__complete", complete.Command(root))
+//	completeGroup := cmdy.NewGroup("Shell completion", "")
+//	completeGroup.Add("install", install.Command())
+//	app.Add("complete", func() cmdy.Command { return completeGroup })
+func Command() cmdy.Builder {
+	return func() cmdy.Command { return &installCommand{} }
+}
+
+type installCommand struct {
+	shell string
+}
+
+func (c *installCommand) Synopsis() string {
+	return "Print a shell snippet that wires up completion"
+}
+
+func (c *installCommand) Usage() string {
+	return "Prints the integration snippet for -shell. Add it to your shell's rc file, or eval it directly:\n\n" +
+		"    eval \"$(myapp complete install -shell bash)\""
+}
+
+func (c *installCommand) Flags() *cmdy.FlagSet {
+	fs := cmdy.NewFlagSet()
+	fs.StringVar(&c.shell, "shell", "bash", "shell to generate a snippet for (bash, zsh, fish)")
+	return fs
+}
+
+func (c *installCommand) Args() *arg.ArgSet { return nil }
+
+func (c *installCommand) Configure(flags *cmdy.FlagSet, args *arg.ArgSet) {}
+
+func (c *installCommand) Run(ctx cmdy.Context) error {
+	prog := strings.Fields(ctx.Name)[0]
+
+	snippet, err := Snippet(Shell(c.shell), prog)
+	if err != nil {
+		return cmdy.UsageError(err)
+	}
+	_, err = ctx.Stdout.Write([]byte(snippet))
+	return err
+}
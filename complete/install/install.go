@@ -0,0 +1,55 @@
+// Package install renders the shell snippets that wire a program's
+// hidden "__complete" subcommand (see cmdy/complete) up to a real
+// shell's completion system, so a program can offer
+// `myapp complete install --shell bash` instead of requiring users to
+// hand-write the incantation themselves.
+package install
+
+import "fmt"
+
+// Shell identifies a supported shell for Snippet.
+type Shell string
+
+const (
+	Bash Shell = "bash"
+	Zsh  Shell = "zsh"
+	Fish Shell = "fish"
+)
+
+// Snippet renders the shell integration snippet that wires prog's
+// "__complete" subcommand up to shell's completion system. It's
+// intended to be eval'd directly, e.g.:
+//
+//	eval "$(myapp complete install --shell bash)"
+func Snippet(shell Shell, prog string) (string, error) {
+	switch shell {
+	case Bash:
+		return fmt.Sprintf(
+			"_%[1]s_complete() {\n"+
+				"	COMPREPLY=($(%[1]s __complete -- \"${COMP_WORDS[@]:1:COMP_CWORD}\"))\n"+
+				"}\n"+
+				"complete -F _%[1]s_complete %[1]s\n",
+			prog,
+		), nil
+
+	case Zsh:
+		return fmt.Sprintf(
+			"_%[1]s_complete() {\n"+
+				"	local -a candidates\n"+
+				"	candidates=(${(f)\"$(%[1]s __complete -- \"${words[@]:1}\")\"})\n"+
+				"	_describe '%[1]s' candidates\n"+
+				"}\n"+
+				"compdef _%[1]s_complete %[1]s\n",
+			prog,
+		), nil
+
+	case Fish:
+		return fmt.Sprintf(
+			"complete -c %[1]s -f -a '(%[1]s __complete -- (commandline -opc)[2..-1] (commandline -ct))'\n",
+			prog,
+		), nil
+
+	default:
+		return "", fmt.Errorf("install: unsupported shell %q", shell)
+	}
+}
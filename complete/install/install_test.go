@@ -0,0 +1,39 @@
+package install
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shabbyrobe/golib/assert"
+)
+
+func TestSnippetBash(t *testing.T) {
+	tt := assert.WrapTB(t)
+
+	got, err := Snippet(Bash, "myapp")
+	tt.MustOK(err)
+	tt.MustAssert(strings.Contains(got, "myapp __complete") && strings.Contains(got, "complete -F _myapp_complete myapp"), got)
+}
+
+func TestSnippetZshReferencesProg(t *testing.T) {
+	tt := assert.WrapTB(t)
+
+	got, err := Snippet(Zsh, "myapp")
+	tt.MustOK(err)
+	tt.MustAssert(strings.Contains(got, "myapp __complete") && strings.Contains(got, "compdef _myapp_complete myapp"), got)
+}
+
+func TestSnippetFishReferencesComplete(t *testing.T) {
+	tt := assert.WrapTB(t)
+
+	got, err := Snippet(Fish, "myapp")
+	tt.MustOK(err)
+	tt.MustAssert(strings.Contains(got, "complete -c myapp") && strings.Contains(got, "myapp __complete"), got)
+}
+
+func TestSnippetUnsupportedShell(t *testing.T) {
+	tt := assert.WrapTB(t)
+
+	_, err := Snippet("powershell", "myapp")
+	tt.MustAssert(err != nil, "expected an error for an unsupported shell")
+}
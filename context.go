@@ -0,0 +1,26 @@
+package cmdy
+
+import (
+	"context"
+	"io"
+)
+
+// Context is passed to Command.Run. It carries the invocation's
+// context.Context alongside the resolved command name and I/O streams,
+// so a Command doesn't need to thread a *Runner through its own API.
+type Context struct {
+	context.Context
+
+	// Name is the full, space-separated invocation path used to reach
+	// the running command, e.g. "myapp server start".
+	Name string
+
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+
+	// Interactive mirrors the Runner's own setting, cleared when -no-input
+	// was given, so a Group can carry it through to the nested Runner it
+	// uses to dispatch to a subcommand.
+	Interactive bool
+}
@@ -3,6 +3,10 @@ package cmdy
 import (
 	"errors"
 	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"runtime"
 	"strings"
 )
 
@@ -85,19 +89,37 @@ func IsUsageError(err error) bool {
 	return errors.As(err, &u)
 }
 
-// ErrCode returns the error code associated with the error if it implements
-// cmdy.Error, or ExitInternal if not.
+// ErrCode returns the error code associated with the error if it (or
+// anything it wraps) implements cmdy.Error, or ExitInternal if not.
 func ErrCode(err error) (code int) {
 	if err == nil {
 		return ExitSuccess
 	}
-	e, ok := err.(Error)
-	if !ok {
+	var e Error
+	if !errors.As(err, &e) {
 		return ExitInternal
 	}
 	return e.Code()
 }
 
+// Trace, when true, makes FormatError append a formatted stack trace
+// after the error message for any error that carries one (see
+// StackTracer). Runner sets this from Runner.Trace, the CMDY_TRACE=1
+// environment variable, or a -trace flag before running a Command, so
+// FormatError (typically called after Runner.Run has already returned)
+// picks it up without needing the error to carry the setting itself.
+var Trace = os.Getenv("CMDY_TRACE") == "1"
+
+// StackTracer is implemented by errors that carry a call stack, as
+// produced by WrapError. FormatError also recognizes any error whose
+// StackTrace() method returns a slice of frames that implement
+// fmt.Formatter, checked structurally via reflection in stackFrames -
+// which covers errors produced by github.com/pkg/errors too, without
+// cmdy needing to depend on that package.
+type StackTracer interface {
+	StackTrace() []Frame
+}
+
 // FormatError builds the output which should be printed to the console.
 //
 // If the error is a usage error, the full help string will be assigned
@@ -112,34 +134,35 @@ func ErrCode(err error) (code int) {
 //
 // Otherwise, msg will contain the result of calling Error().
 //
+// If Trace is true and err (or anything it wraps) implements
+// StackTracer, a formatted stack trace is appended after the message.
 func FormatError(err error) (msg string, code int) {
 	if err == nil {
 		return "", ExitSuccess
 	}
 
-	switch err := err.(type) {
+	switch e := err.(type) {
 	case QuietExit:
 		// If we don't return here, a code of '0' will be interpreted as an
 		// ExitFailure. In the case of QuietExit, it's a little bit less
 		// natural to assume '0' means we want a non-zero exit status even
 		// though we are technically returning an error.
-		return "", err.Code()
+		return "", e.Code()
 
 	case *usageError:
 		// usageError.usage is lazily populated in Runner.Run() before it is returned:
-		msg = strings.TrimSpace(err.usage)
-		code = err.Code()
+		msg = strings.TrimSpace(e.usage)
+		code = e.Code()
 
-		if err.err != nil {
+		if e.err != nil {
 			if msg != "" {
 				msg += "\n\n"
 			}
-			msg += "error: " + err.err.Error()
+			msg += "error: " + e.err.Error()
 		}
-		return msg, code
 
 	case Error:
-		return err.Error(), err.Code()
+		msg, code = e.Error(), e.Code()
 
 	case errorGroup:
 		withCode, _ := err.(interface{ Code() int })
@@ -147,17 +170,140 @@ func FormatError(err error) (msg string, code int) {
 		if withCode != nil {
 			code = withCode.Code()
 		}
-		for i, e := range err.Errors() {
+		for i, sub := range e.Errors() {
 			if i != 0 {
 				msg += "\n"
 			}
-			msg += "- " + e.Error()
+			msg += "- " + sub.Error()
+			if Trace {
+				msg = appendStack(msg, sub)
+			}
 		}
 		return msg, code
 
 	default:
-		return err.Error(), ExitFailure
+		msg, code = err.Error(), ExitFailure
+	}
+
+	if Trace {
+		msg = appendStack(msg, err)
+	}
+	return msg, code
+}
+
+// WrapError annotates err with a stack trace captured at the call site
+// (via runtime.Callers), so command authors can get FormatError's Trace
+// output without pulling in an external stack-trace dependency. The
+// trace is trimmed once it reaches Runner.run, so callers don't see Go's
+// own runtime/goroutine bootstrap frames.
+//
+// WrapError returns nil if err is nil.
+func WrapError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var pcs [64]uintptr
+	n := runtime.Callers(2, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var stack []Frame
+	for {
+		f, more := frames.Next()
+		if strings.Contains(f.Function, "(*Runner).run") || strings.HasPrefix(f.Function, "runtime.") {
+			break
+		}
+		stack = append(stack, Frame{function: f.Function, file: f.File, line: f.Line})
+		if !more {
+			break
+		}
+	}
+	return &tracedError{err: err, stack: stack}
+}
+
+// Frame is a single entry in a call stack captured by WrapError. It
+// implements fmt.Formatter so it can be rendered with "%+v", printing
+// "file:line function" in the style used for cmdy's -trace output.
+type Frame struct {
+	function string
+	file     string
+	line     int
+}
+
+func (f Frame) Format(s fmt.State, verb rune) {
+	if verb == 'v' && s.Flag('+') {
+		fmt.Fprintf(s, "\n\t%s:%d %s", f.file, f.line, f.function)
+		return
+	}
+	io.WriteString(s, f.function)
+}
+
+type tracedError struct {
+	err   error
+	stack []Frame
+}
+
+func (t *tracedError) Error() string       { return t.err.Error() }
+func (t *tracedError) Unwrap() error       { return t.err }
+func (t *tracedError) StackTrace() []Frame { return t.stack }
+
+// stackFrames returns the formattable stack frames carried by err or
+// anything it wraps, or nil if none of them carry one.
+func stackFrames(err error) []fmt.Formatter {
+	for err != nil {
+		if st, ok := err.(StackTracer); ok {
+			frames := st.StackTrace()
+			out := make([]fmt.Formatter, len(frames))
+			for i, f := range frames {
+				out[i] = f
+			}
+			return out
+		}
+		if frames, ok := reflectStackTrace(err); ok {
+			return frames
+		}
+		err = errors.Unwrap(err)
+	}
+	return nil
+}
+
+// reflectStackTrace detects errors exposing a StackTrace() method whose
+// return type isn't our own Frame type - e.g. github.com/pkg/errors'
+// errors.StackTrace - by checking the shape structurally instead of by
+// name, so cmdy can render those stacks without importing that package.
+func reflectStackTrace(err error) ([]fmt.Formatter, bool) {
+	m := reflect.ValueOf(err).MethodByName("StackTrace")
+	if !m.IsValid() || m.Type().NumIn() != 0 || m.Type().NumOut() != 1 {
+		return nil, false
+	}
+	out := m.Call(nil)[0]
+	if out.Kind() != reflect.Slice {
+		return nil, false
+	}
+	frames := make([]fmt.Formatter, 0, out.Len())
+	for i := 0; i < out.Len(); i++ {
+		f, ok := out.Index(i).Interface().(fmt.Formatter)
+		if !ok {
+			return nil, false
+		}
+		frames = append(frames, f)
+	}
+	return frames, true
+}
+
+// appendStack appends the formatted stack trace carried by err (if any)
+// to msg, on its own indented lines.
+func appendStack(msg string, err error) string {
+	frames := stackFrames(err)
+	if len(frames) == 0 {
+		return msg
+	}
+	var b strings.Builder
+	b.WriteString(msg)
+	for _, f := range frames {
+		fmt.Fprintf(&b, "%+v", f)
 	}
+	return b.String()
 }
 
 type exitError struct {
@@ -0,0 +1,54 @@
+package cmdy
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/shabbyrobe/golib/assert"
+)
+
+func TestFormatErrorTraceOffIsUnchanged(t *testing.T) {
+	tt := assert.WrapTB(t)
+	defer func(old bool) { Trace = old }(Trace)
+	Trace = false
+
+	err := WrapError(errors.New("boom"))
+	msg, code := FormatError(err)
+	tt.MustEqual("boom", msg)
+	tt.MustEqual(ExitFailure, code)
+}
+
+func TestFormatErrorTraceOnAppendsStack(t *testing.T) {
+	tt := assert.WrapTB(t)
+	defer func(old bool) { Trace = old }(Trace)
+	Trace = true
+
+	err := WrapError(errors.New("boom"))
+	msg, _ := FormatError(err)
+	tt.MustAssert(strings.HasPrefix(msg, "boom\n\t"), msg)
+	tt.MustAssert(strings.Contains(msg, "error_trace_test.go"), msg)
+}
+
+func TestFormatErrorTraceGroupIncludesPerEntryStacks(t *testing.T) {
+	tt := assert.WrapTB(t)
+	defer func(old bool) { Trace = old }(Trace)
+	Trace = true
+
+	g := testErrorGroup{errs: []error{WrapError(errors.New("one")), errors.New("two")}}
+	msg, code := FormatError(g)
+	tt.MustEqual(ExitFailure, code)
+	tt.MustAssert(strings.Contains(msg, "- one\n\t"), msg)
+	tt.MustAssert(strings.Contains(msg, "- two") && !strings.Contains(msg, "- two\n\t"), msg)
+}
+
+func TestErrCodeSeesThroughWrapError(t *testing.T) {
+	tt := assert.WrapTB(t)
+	err := WrapError(ErrWithCode(42, errors.New("boom")))
+	tt.MustEqual(42, ErrCode(err))
+}
+
+type testErrorGroup struct{ errs []error }
+
+func (g testErrorGroup) Error() string   { return "error group" }
+func (g testErrorGroup) Errors() []error { return g.errs }
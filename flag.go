@@ -0,0 +1,366 @@
+package cmdy
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	arg "github.com/ArtProcessors/cmdy/args"
+)
+
+// FlagDoubleDash controls whether multi-character flag names are shown
+// with a "--" prefix in Usage() output. Single-character names always
+// use "-". This only affects rendering; both forms are always accepted
+// when parsing.
+var FlagDoubleDash = false
+
+// Hinter may be implemented by a flag value to control how it is
+// rendered in FlagSet.Usage(): kind is shown as "=<kind>" after the flag
+// name, and hint is appended verbatim after that, separated by a space.
+type Hinter interface {
+	Hint() (kind, hint string)
+}
+
+// Candidate and Completer are aliases for their arg package
+// counterparts (see arg.Candidate and arg.Completer), so a single flag
+// value can drive completion whether it's registered as a flag or as a
+// positional argument.
+type Candidate = arg.Candidate
+type Completer = arg.Completer
+
+// FlagSet collects a Command's options, in the style of the standard
+// library's flag.FlagSet but with richer Usage() output driven by
+// Hinter.
+type FlagSet struct {
+	fs *flag.FlagSet
+
+	// EnvPrefix, if set, is prepended (upper-cased, with a trailing
+	// underscore) to the mapped name of every env-bound flag.
+	EnvPrefix string
+
+	// EnvNameFunc, if set, overrides the default flagName -> env var
+	// name mapping used by EnvName.
+	EnvNameFunc func(flagName string) string
+
+	automaticEnv bool
+	boundEnv     map[string]bool
+}
+
+// NewFlagSet builds an empty FlagSet.
+func NewFlagSet() *FlagSet {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.Usage = func() {}
+	fs.SetOutput(io.Discard)
+	return &FlagSet{fs: fs}
+}
+
+// BindEnv marks the named flags as env-bound: if a matching environment
+// variable is set, it is applied before argv is parsed. An explicit CLI
+// flag always takes precedence over the env var, which in turn takes
+// precedence over the flag's default.
+func (f *FlagSet) BindEnv(names ...string) {
+	if f.boundEnv == nil {
+		f.boundEnv = map[string]bool{}
+	}
+	for _, name := range names {
+		f.boundEnv[name] = true
+	}
+}
+
+// AutomaticEnv binds every flag registered on this FlagSet to env,
+// present and future, following the same precedence as BindEnv.
+func (f *FlagSet) AutomaticEnv() {
+	f.automaticEnv = true
+}
+
+func (f *FlagSet) isEnvBound(name string) bool {
+	return f.automaticEnv || f.boundEnv[name]
+}
+
+// EnvName returns the environment variable a bound flag is read from,
+// honoring EnvPrefix and EnvNameFunc. The default mapping upper-cases
+// the flag name and replaces "-" and "." with "_", e.g. "log-level"
+// under prefix "myapp" becomes "MYAPP_LOG_LEVEL".
+func (f *FlagSet) EnvName(flagName string) string {
+	if f.EnvNameFunc != nil {
+		return f.EnvNameFunc(flagName)
+	}
+
+	mapped := strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(flagName))
+	if f.EnvPrefix == "" {
+		return mapped
+	}
+	return strings.ToUpper(f.EnvPrefix) + "_" + mapped
+}
+
+// applyEnv sets every env-bound flag whose environment variable is
+// present, ahead of argv parsing so that explicit CLI flags still win.
+func (f *FlagSet) applyEnv() error {
+	var firstErr error
+	f.fs.VisitAll(func(fl *flag.Flag) {
+		if firstErr != nil || !f.isEnvBound(fl.Name) {
+			return
+		}
+		name := f.EnvName(fl.Name)
+		val, ok := os.LookupEnv(name)
+		if !ok {
+			return
+		}
+		if err := fl.Value.Set(val); err != nil {
+			firstErr = fmt.Errorf("env %s: %w", name, err)
+		}
+	})
+	return firstErr
+}
+
+// Var registers a custom flag.Value. If value also implements Hinter,
+// its kind/hint annotate the flag in Usage().
+func (f *FlagSet) Var(value flag.Value, name, usage string) {
+	f.fs.Var(value, name, usage)
+}
+
+func (f *FlagSet) BoolVar(p *bool, name string, value bool, usage string) {
+	*p = value
+	f.fs.Var(&boolValue{p: p}, name, usage)
+}
+
+func (f *FlagSet) StringVar(p *string, name, value, usage string) {
+	*p = value
+	f.fs.Var(&stringValue{p: p}, name, usage)
+}
+
+func (f *FlagSet) IntVar(p *int, name string, value int, usage string) {
+	*p = value
+	f.fs.Var(&intValue{p: p}, name, usage)
+}
+
+func (f *FlagSet) DurationVar(p *time.Duration, name string, value time.Duration, usage string) {
+	*p = value
+	f.fs.Var(&durationValue{p: p}, name, usage)
+}
+
+// Parse parses argv against the registered flags, stopping at the first
+// non-flag argument. Remaining arguments are available via Args().
+//
+// A request for -help/-h is reported as a HelpRequest error. Any other
+// parse failure is reported as a UsageError so FormatError renders it
+// consistently with command-level errors.
+func (f *FlagSet) Parse(argv []string) error {
+	if err := f.applyEnv(); err != nil {
+		return UsageError(err)
+	}
+	if err := f.fs.Parse(argv); err != nil {
+		if err == flag.ErrHelp {
+			return HelpRequest()
+		}
+		return UsageError(err)
+	}
+	return nil
+}
+
+// Args returns the arguments remaining after the last flag was parsed.
+func (f *FlagSet) Args() []string { return f.fs.Args() }
+
+// Names returns the registered flag names, sorted - used by
+// cmdy/complete to offer flag-name candidates.
+func (f *FlagSet) Names() []string {
+	var names []string
+	f.fs.VisitAll(func(fl *flag.Flag) { names = append(names, fl.Name) })
+	sort.Strings(names)
+	return names
+}
+
+// Value returns the registered flag.Value for name, if any - used by
+// cmdy/complete to offer completions for a flag's argument when that
+// value implements Completer.
+func (f *FlagSet) Value(name string) (flag.Value, bool) {
+	fl := f.fs.Lookup(name)
+	if fl == nil {
+		return nil, false
+	}
+	return fl.Value, true
+}
+
+// Prefix returns the "-" or "--" prefix Usage() would render before
+// name, honoring FlagDoubleDash.
+func (f *FlagSet) Prefix(name string) string { return flagPrefix(name) }
+
+// Usage renders the registered flags, one per line, sorted by name and
+// word-wrapped to a fixed width, in the style used throughout cmdy's own
+// -help output.
+func (f *FlagSet) Usage() string {
+	var b strings.Builder
+	f.fs.VisitAll(func(fl *flag.Flag) {
+		b.WriteString(f.lineFor(fl))
+	})
+	return b.String()
+}
+
+const flagUsageWidth = 88
+
+func (f *FlagSet) lineFor(fl *flag.Flag) string {
+	prefix := flagPrefix(fl.Name)
+	header := "  " + prefix + fl.Name
+
+	if !isBoolFlag(fl.Value) {
+		kind, hint := "", ""
+		if h, ok := fl.Value.(Hinter); ok {
+			kind, hint = h.Hint()
+		}
+		if kind != "" {
+			header += "=<" + kind + ">"
+		}
+		if hint != "" {
+			header += " " + hint
+		}
+	}
+
+	body := f.bodyFor(fl)
+	newline := prefix == "--" || len(header) >= 8
+
+	var b strings.Builder
+	b.WriteString(header)
+
+	if body == "" {
+		if newline {
+			b.WriteString("\n")
+		} else {
+			b.WriteString(strings.Repeat(" ", 8-len(header)))
+			b.WriteString("\n")
+		}
+		return b.String()
+	}
+
+	if newline {
+		b.WriteString("\n        ")
+	} else {
+		b.WriteString(strings.Repeat(" ", 8-len(header)))
+	}
+	b.WriteString(wrapWords(body, 8, flagUsageWidth))
+	b.WriteString("\n")
+	return b.String()
+}
+
+func (f *FlagSet) bodyFor(fl *flag.Flag) string {
+	if fl.Usage == "" {
+		return ""
+	}
+	body := fl.Usage + " (default: " + formatDefault(fl) + ")"
+	if f.isEnvBound(fl.Name) {
+		body += " (env: " + f.EnvName(fl.Name) + ")"
+	}
+	return body
+}
+
+func formatDefault(fl *flag.Flag) string {
+	if _, ok := fl.Value.(*stringValue); ok {
+		return fmt.Sprintf("%q", fl.DefValue)
+	}
+	return fl.DefValue
+}
+
+func flagPrefix(name string) string {
+	if FlagDoubleDash && len(name) > 1 {
+		return "--"
+	}
+	return "-"
+}
+
+func isBoolFlag(v flag.Value) bool {
+	b, ok := v.(interface{ IsBoolFlag() bool })
+	return ok && b.IsBoolFlag()
+}
+
+// wrapWords greedily wraps the words in s so that no rendered line
+// exceeds width, continuing wrapped lines at the given indent column.
+func wrapWords(s string, col, width int) string {
+	words := strings.Fields(s)
+	var b strings.Builder
+	cur := col
+	for i, w := range words {
+		if i > 0 {
+			if cur+1+len(w) > width {
+				b.WriteString("\n" + strings.Repeat(" ", col))
+				cur = col
+			} else {
+				b.WriteString(" ")
+				cur++
+			}
+		}
+		b.WriteString(w)
+		cur += len(w)
+	}
+	return b.String()
+}
+
+type boolValue struct{ p *bool }
+
+func (v *boolValue) String() string {
+	if v.p == nil {
+		return "false"
+	}
+	return strconv.FormatBool(*v.p)
+}
+func (v *boolValue) Set(s string) error {
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+	*v.p = b
+	return nil
+}
+func (v *boolValue) IsBoolFlag() bool { return true }
+
+type stringValue struct{ p *string }
+
+func (v *stringValue) String() string {
+	if v.p == nil {
+		return ""
+	}
+	return *v.p
+}
+func (v *stringValue) Set(s string) error        { *v.p = s; return nil }
+func (v *stringValue) Hint() (kind, hint string) { return "string", "" }
+
+type intValue struct{ p *int }
+
+func (v *intValue) String() string {
+	if v.p == nil {
+		return "0"
+	}
+	return strconv.Itoa(*v.p)
+}
+func (v *intValue) Set(s string) error {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return err
+	}
+	*v.p = n
+	return nil
+}
+func (v *intValue) Hint() (kind, hint string) { return "int", "" }
+
+type durationValue struct{ p *time.Duration }
+
+func (v *durationValue) String() string {
+	if v.p == nil {
+		return "0s"
+	}
+	return v.p.String()
+}
+func (v *durationValue) Set(s string) error {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*v.p = d
+	return nil
+}
+func (v *durationValue) Hint() (kind, hint string) {
+	return "duration", "(formats: '1h2s', '-3.4ms', units: h, m, s, ms, us, ns)"
+}
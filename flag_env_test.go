@@ -0,0 +1,92 @@
+package cmdy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shabbyrobe/golib/assert"
+)
+
+func TestFlagSetEnvPrecedence(t *testing.T) {
+	tt := assert.WrapTB(t)
+	t.Setenv("MYAPP_LOG_LEVEL", "debug")
+
+	var level string
+	fs := NewFlagSet()
+	fs.EnvPrefix = "myapp"
+	fs.StringVar(&level, "log-level", "info", "logging level")
+	fs.BindEnv("log-level")
+
+	tt.MustOK(fs.Parse(nil))
+	tt.MustEqual("debug", level)
+}
+
+func TestFlagSetEnvLosesToCLI(t *testing.T) {
+	tt := assert.WrapTB(t)
+	t.Setenv("MYAPP_LOG_LEVEL", "debug")
+
+	var level string
+	fs := NewFlagSet()
+	fs.EnvPrefix = "myapp"
+	fs.StringVar(&level, "log-level", "info", "logging level")
+	fs.BindEnv("log-level")
+
+	tt.MustOK(fs.Parse([]string{"-log-level", "trace"}))
+	tt.MustEqual("trace", level)
+}
+
+func TestFlagSetAutomaticEnv(t *testing.T) {
+	tt := assert.WrapTB(t)
+	t.Setenv("PORT", "9090")
+
+	var port int
+	fs := NewFlagSet()
+	fs.IntVar(&port, "port", 8080, "listen port")
+	fs.AutomaticEnv()
+
+	tt.MustOK(fs.Parse(nil))
+	tt.MustEqual(9090, port)
+}
+
+func TestFlagSetEnvParseErrorIsUsageError(t *testing.T) {
+	tt := assert.WrapTB(t)
+	t.Setenv("PORT", "not-a-number")
+
+	var port int
+	fs := NewFlagSet()
+	fs.IntVar(&port, "port", 8080, "listen port")
+	fs.BindEnv("port")
+
+	err := fs.Parse(nil)
+	tt.MustAssert(IsUsageError(err), err)
+}
+
+func TestFlagSetEnvNameOverride(t *testing.T) {
+	tt := assert.WrapTB(t)
+	fs := NewFlagSet()
+	fs.EnvPrefix = "myapp"
+	fs.EnvNameFunc = func(name string) string { return "CUSTOM_" + name }
+
+	tt.MustEqual("CUSTOM_log-level", fs.EnvName("log-level"))
+}
+
+func TestFlagSetEnvUsageShowsEnvVar(t *testing.T) {
+	tt := assert.WrapTB(t)
+
+	var level string
+	fs := NewFlagSet()
+	fs.EnvPrefix = "myapp"
+	fs.StringVar(&level, "log-level", "info", "logging level")
+	fs.BindEnv("log-level")
+
+	tt.MustAssert(strings.Contains(fs.Usage(), "(env: MYAPP_LOG_LEVEL)"), fs.Usage())
+}
+
+func TestFlagSetEnvNameMapping(t *testing.T) {
+	tt := assert.WrapTB(t)
+	fs := NewFlagSet()
+	fs.EnvPrefix = "myapp"
+
+	tt.MustEqual("MYAPP_LOG_LEVEL", fs.EnvName("log-level"))
+	tt.MustEqual("MYAPP_DB_HOST", fs.EnvName("db.host"))
+}
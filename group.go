@@ -0,0 +1,94 @@
+package cmdy
+
+import (
+	arg "github.com/ArtProcessors/cmdy/args"
+)
+
+// Group dispatches to a set of named subcommands, e.g. "myapp server
+// start". It implements Command itself, so groups can be nested inside
+// other groups.
+//
+// A Group has no flags or positional arguments of its own beyond the
+// subcommand name and its arguments, which it forwards verbatim to a
+// nested Runner.
+type Group struct {
+	synopsis string
+	usage    string
+
+	subs   map[string]Builder
+	hidden map[string]Builder
+
+	rest []string
+}
+
+// NewGroup builds an empty Group.
+func NewGroup(synopsis, usage string) *Group {
+	return &Group{synopsis: synopsis, usage: usage, subs: map[string]Builder{}}
+}
+
+// Add registers a named subcommand, shown in usage text and man pages
+// and offered as a shell completion candidate.
+func (g *Group) Add(name string, b Builder) {
+	g.subs[name] = b
+}
+
+// AddHidden registers a named subcommand that can still be dispatched
+// to, but is omitted from usage text, man pages and completion
+// candidates. It exists so cross-cutting packages (e.g. cmdy/complete)
+// can wire up their own plumbing subcommands without cluttering -help.
+func (g *Group) AddHidden(name string, b Builder) {
+	if g.hidden == nil {
+		g.hidden = map[string]Builder{}
+	}
+	g.hidden[name] = b
+}
+
+// Subcommands returns every visible subcommand registered with Add,
+// keyed by name. It does not include subcommands registered with
+// AddHidden.
+func (g *Group) Subcommands() map[string]Builder { return g.subs }
+
+func (g *Group) lookup(name string) (Builder, bool) {
+	if b, ok := g.subs[name]; ok {
+		return b, true
+	}
+	if b, ok := g.hidden[name]; ok {
+		return b, true
+	}
+	return nil, false
+}
+
+func (g *Group) Synopsis() string { return g.synopsis }
+func (g *Group) Usage() string    { return g.usage }
+
+func (g *Group) Flags() *FlagSet { return nil }
+
+func (g *Group) Args() *arg.ArgSet {
+	as := arg.NewArgSet()
+	as.Remaining(&g.rest, "command", arg.Min(1), "the subcommand to run, and any arguments to pass to it")
+	return as
+}
+
+func (g *Group) Configure(flags *FlagSet, args *arg.ArgSet) {}
+
+// Run dispatches to the chosen subcommand with a nested Runner,
+// forwarding the parent's I/O streams and its Interactive and Trace
+// settings.
+func (g *Group) Run(c Context) error {
+	name, rest := g.rest[0], g.rest[1:]
+
+	b, ok := g.lookup(name)
+	if !ok {
+		return UsageErrorf("unknown subcommand %q", name)
+	}
+
+	r := &Runner{
+		Stdin:       c.Stdin,
+		Stdout:      c.Stdout,
+		Stderr:      c.Stderr,
+		Name:        c.Name + " " + name,
+		Interactive: c.Interactive,
+		Trace:       Trace,
+	}
+	return r.run(c.Context, r.Name, rest, b)
+}
@@ -0,0 +1,66 @@
+package cmdy
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/shabbyrobe/golib/assert"
+)
+
+func TestGroupDispatchesToSubcommand(t *testing.T) {
+	tt := assert.WrapTB(t)
+
+	var gotName string
+	sub := testCmdRunBuilder(func(c Context) error {
+		gotName = c.Name
+		return nil
+	})
+
+	g := NewGroup("a group", "")
+	g.Add("sub", sub)
+
+	tt.MustOK(Run(context.Background(), []string{"sub"}, testBuilder(g)))
+	tt.MustEqual("cmdy.test sub", gotName)
+}
+
+func TestGroupUnknownSubcommand(t *testing.T) {
+	tt := assert.WrapTB(t)
+
+	g := NewGroup("a group", "")
+	g.Add("sub", testCmdRunBuilder(func(Context) error { return nil }))
+
+	err := Run(context.Background(), []string{"nope"}, testBuilder(g))
+	tt.MustAssert(IsUsageError(err) && strings.Contains(err.Error(), `unknown subcommand "nope"`), err)
+}
+
+func TestGroupHiddenSubcommandIsDispatchedButNotListed(t *testing.T) {
+	tt := assert.WrapTB(t)
+
+	g := NewGroup("a group", "")
+	g.AddHidden("__complete", testCmdRunBuilder(func(Context) error { return nil }))
+
+	_, ok := g.Subcommands()["__complete"]
+	tt.MustAssert(!ok, "expected hidden subcommand to be excluded from Subcommands()")
+
+	tt.MustOK(Run(context.Background(), []string{"__complete"}, testBuilder(g)))
+}
+
+func TestGroupForwardsInteractive(t *testing.T) {
+	tt := assert.WrapTB(t)
+
+	var gotInteractive bool
+	sub := testCmdRunBuilder(func(c Context) error {
+		gotInteractive = c.Interactive
+		return nil
+	})
+
+	g := NewGroup("a group", "")
+	g.Add("sub", sub)
+
+	r := newTestRunner()
+	r.Interactive = true
+
+	tt.MustOK(r.Run(context.Background(), []string{"sub"}, testBuilder(g)))
+	tt.MustAssert(gotInteractive, "expected Interactive to be forwarded to the nested Context")
+}
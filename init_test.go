@@ -3,7 +3,7 @@ package cmdy
 import (
 	"bytes"
 
-	"github.com/ArtProcessors/cmdy/arg"
+	arg "github.com/ArtProcessors/cmdy/args"
 )
 
 type testCmd struct {
@@ -0,0 +1,202 @@
+// Package manpage renders troff man pages from a cmdy.Command tree.
+//
+// It walks a Builder (recursing into every Group subcommand) and emits
+// one page per command, built from the same Synopsis(), Usage(),
+// FlagSet and arg.ArgSet metadata that already drives -help output. This
+// lets a project ship real man(1) pages without maintaining a separate
+// markdown-to-man pipeline.
+package manpage
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ArtProcessors/cmdy"
+	arg "github.com/ArtProcessors/cmdy/args"
+)
+
+// Options controls the metadata written into the header and footer of
+// every generated page.
+type Options struct {
+	// Section is the man section number, e.g. "1" for user commands.
+	// Defaults to "1".
+	Section string
+
+	// Source is rendered in the page footer and Manual in the page
+	// header, e.g. "cmdy-mkman" and "User Commands".
+	Source string
+	Manual string
+
+	// Date is rendered in the page footer. Defaults to time.Now() if
+	// zero.
+	Date time.Time
+
+	// SeeAlso lists additional names appended to the SEE ALSO section
+	// of the root page, beyond its own subcommands.
+	SeeAlso []string
+}
+
+// commandGroup is satisfied by cmdy.Group. manpage only needs enough of
+// its surface to discover and recurse into subcommands.
+type commandGroup interface {
+	Subcommands() map[string]cmdy.Builder
+}
+
+// Generate walks the Command tree rooted at builder(), returning one
+// rendered page per command (including every Group subcommand,
+// recursively). The result is keyed by page name in the conventional
+// "prog-sub-sub" form, without the trailing ".<section>" suffix.
+//
+// name seeds that path: cmdy.Builder has no way to report the program's
+// own name (a Group only knows the names of its subcommands), so the
+// root name must be supplied by the caller rather than derived from the
+// tree itself.
+func Generate(name string, builder cmdy.Builder, opts Options) (map[string][]byte, error) {
+	if opts.Section == "" {
+		opts.Section = "1"
+	}
+	if opts.Date.IsZero() {
+		opts.Date = time.Now()
+	}
+
+	pages := map[string][]byte{}
+	if err := generate([]string{name}, builder, opts, pages); err != nil {
+		return nil, err
+	}
+	return pages, nil
+}
+
+func generate(path []string, builder cmdy.Builder, opts Options, pages map[string][]byte) error {
+	cmd := builder()
+
+	flags := cmd.Flags()
+	if flags == nil {
+		flags = cmdy.NewFlagSet()
+	}
+	args := cmd.Args()
+	if args == nil {
+		args = arg.NewArgSet()
+	}
+	cmd.Configure(flags, args)
+
+	pageName := strings.Join(path, "-")
+	pages[pageName] = render(path, cmd, flags, args, opts)
+
+	grp, isGroup := cmd.(commandGroup)
+	if !isGroup {
+		return nil
+	}
+
+	for _, n := range subcommandNames(grp) {
+		childPath := append(append([]string{}, path...), n)
+		if err := generate(childPath, grp.Subcommands()[n], opts, pages); err != nil {
+			return fmt.Errorf("manpage: %s: %w", strings.Join(childPath, "-"), err)
+		}
+	}
+	return nil
+}
+
+func subcommandNames(grp commandGroup) []string {
+	names := make([]string, 0, len(grp.Subcommands()))
+	for n := range grp.Subcommands() {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func render(path []string, cmd cmdy.Command, flags *cmdy.FlagSet, args *arg.ArgSet, opts Options) []byte {
+	full := strings.Join(path, " ")
+	title := strings.ToUpper(strings.Join(path, "-"))
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, ".TH %s %s \"%s\" \"%s\" \"%s\"\n",
+		title, opts.Section, opts.Date.Format("January 2006"), opts.Source, opts.Manual)
+
+	b.WriteString(".SH NAME\n")
+	fmt.Fprintf(&b, "%s", escape(full))
+	if syn := cmd.Synopsis(); syn != "" {
+		fmt.Fprintf(&b, " \\- %s", escape(syn))
+	}
+	b.WriteString("\n")
+
+	b.WriteString(".SH SYNOPSIS\n")
+	fmt.Fprintf(&b, ".B %s\n[options] %s\n", escape(full), escape(args.Synopsis()))
+
+	if desc := strings.TrimSpace(cmd.Usage()); desc != "" {
+		b.WriteString(".SH DESCRIPTION\n")
+		b.WriteString(escape(desc))
+		b.WriteString("\n")
+	}
+
+	if opt := strings.TrimRight(flags.Usage(), "\n"); opt != "" {
+		b.WriteString(".SH OPTIONS\n")
+		writeDefinitionList(&b, opt)
+	}
+
+	if argUsage := strings.TrimRight(args.Usage(), "\n"); argUsage != "" {
+		b.WriteString(".SH ARGUMENTS\n")
+		writeDefinitionList(&b, argUsage)
+	}
+
+	if refs := seeAlso(path, cmd, opts); len(refs) > 0 {
+		b.WriteString(".SH SEE ALSO\n")
+		b.WriteString(strings.Join(refs, ",\n"))
+		b.WriteString("\n")
+	}
+
+	return b.Bytes()
+}
+
+func seeAlso(path []string, cmd cmdy.Command, opts Options) []string {
+	var refs []string
+	if grp, ok := cmd.(commandGroup); ok {
+		for _, n := range subcommandNames(grp) {
+			refs = append(refs, fmt.Sprintf(".BR %s-%s (%s)", strings.Join(path, "-"), n, opts.Section))
+		}
+	}
+	if len(path) == 1 {
+		refs = append(refs, opts.SeeAlso...)
+	}
+	return refs
+}
+
+// writeDefinitionList converts the "  <header>" lines and the
+// 8-space-indented description lines under them, as produced by
+// FlagSet.Usage() / arg.ArgSet.Usage(), into a troff definition list,
+// one .TP entry per flag or argument. A flag short enough for
+// FlagSet.Usage() to start its description on the header's own line
+// keeps that first line in the .TP header.
+func writeDefinitionList(b *bytes.Buffer, usage string) {
+	for _, block := range strings.Split(strings.ReplaceAll(usage, "\n        ", "\n\t"), "\n  ") {
+		block = strings.TrimPrefix(block, "  ")
+		lines := strings.SplitN(block, "\n", 2)
+		header := strings.TrimSpace(lines[0])
+		if header == "" {
+			continue
+		}
+
+		b.WriteString(".TP\n")
+		b.WriteString(escape(header))
+		b.WriteString("\n")
+		if len(lines) > 1 {
+			if desc := strings.TrimSpace(strings.Join(strings.Fields(lines[1]), " ")); desc != "" {
+				b.WriteString(escape(desc))
+				b.WriteString("\n")
+			}
+		}
+	}
+}
+
+// escape neutralises troff control characters so that arbitrary command
+// and flag text can't corrupt the page.
+func escape(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	if strings.HasPrefix(s, ".") || strings.HasPrefix(s, "'") {
+		s = `\&` + s
+	}
+	return s
+}
@@ -0,0 +1,109 @@
+package manpage
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ArtProcessors/cmdy"
+	arg "github.com/ArtProcessors/cmdy/args"
+	"github.com/shabbyrobe/golib/assert"
+)
+
+type fakeCmd struct {
+	synopsis, usage string
+	configure       func(flags *cmdy.FlagSet, args *arg.ArgSet)
+}
+
+func (f *fakeCmd) Synopsis() string     { return f.synopsis }
+func (f *fakeCmd) Usage() string        { return f.usage }
+func (f *fakeCmd) Flags() *cmdy.FlagSet { return nil }
+func (f *fakeCmd) Args() *arg.ArgSet    { return nil }
+func (f *fakeCmd) Configure(flags *cmdy.FlagSet, args *arg.ArgSet) {
+	if f.configure != nil {
+		f.configure(flags, args)
+	}
+}
+func (f *fakeCmd) Run(c cmdy.Context) error { return nil }
+
+type fakeGroup struct {
+	fakeCmd
+	sub map[string]cmdy.Builder
+}
+
+func (f *fakeGroup) Subcommands() map[string]cmdy.Builder { return f.sub }
+
+func TestGenerateSinglePage(t *testing.T) {
+	tt := assert.WrapTB(t)
+
+	root := func() cmdy.Command {
+		return &fakeCmd{
+			synopsis: "does a thing",
+			usage:    "root does a thing, in detail.",
+			configure: func(flags *cmdy.FlagSet, args *arg.ArgSet) {
+				var verbose bool
+				flags.BoolVar(&verbose, "verbose", false, "be noisy")
+				var target string
+				args.String(&target, "target", "what to operate on")
+			},
+		}
+	}
+
+	pages, err := Generate("myapp", root, Options{})
+	tt.MustOK(err)
+	tt.MustEqual(1, len(pages))
+
+	page := string(pages["myapp"])
+	for _, want := range []string{".TH MYAPP 1", ".SH NAME", ".SH SYNOPSIS", ".SH DESCRIPTION", ".SH OPTIONS", ".SH ARGUMENTS"} {
+		tt.MustAssert(strings.Contains(page, want), page)
+	}
+}
+
+func TestGenerateRecursesIntoGroups(t *testing.T) {
+	tt := assert.WrapTB(t)
+
+	leaf := func() cmdy.Command { return &fakeCmd{synopsis: "leaf"} }
+
+	root := func() cmdy.Command {
+		return &fakeGroup{
+			fakeCmd: fakeCmd{synopsis: "root group"},
+			sub:     map[string]cmdy.Builder{"start": leaf, "stop": leaf},
+		}
+	}
+
+	pages, err := Generate("myapp", root, Options{})
+	tt.MustOK(err)
+
+	for _, name := range []string{"myapp", "myapp-start", "myapp-stop"} {
+		_, ok := pages[name]
+		tt.MustAssert(ok, keys(pages))
+	}
+
+	tt.MustAssert(strings.Contains(string(pages["myapp"]), ".SH SEE ALSO"), pages["myapp"])
+}
+
+func TestGenerateSeeAlsoOnlyAppliesToRootPage(t *testing.T) {
+	tt := assert.WrapTB(t)
+
+	leaf := func() cmdy.Command { return &fakeCmd{synopsis: "leaf"} }
+
+	root := func() cmdy.Command {
+		return &fakeGroup{
+			fakeCmd: fakeCmd{synopsis: "root group"},
+			sub:     map[string]cmdy.Builder{"start": leaf},
+		}
+	}
+
+	pages, err := Generate("myapp", root, Options{SeeAlso: []string{".BR other (1)"}})
+	tt.MustOK(err)
+
+	tt.MustAssert(strings.Contains(string(pages["myapp"]), ".BR other (1)"), pages["myapp"])
+	tt.MustAssert(!strings.Contains(string(pages["myapp-start"]), ".BR other (1)"), pages["myapp-start"])
+}
+
+func keys(m map[string][]byte) []string {
+	var out []string
+	for k := range m {
+		out = append(out, k)
+	}
+	return out
+}
@@ -0,0 +1,144 @@
+package cmdy
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	arg "github.com/ArtProcessors/cmdy/args"
+)
+
+// Runner builds and executes a Command: it wires up its flags and
+// positional arguments from argv, applies -help/usage handling, and
+// invokes Command.Run.
+type Runner struct {
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+
+	// Name overrides the program name used in generated usage text. If
+	// empty, the base name of os.Args[0] is used.
+	Name string
+
+	// Interactive enables prompting on Stderr for missing required
+	// positional arguments when Stdin is attached to a terminal. It has
+	// no effect when Stdin isn't a terminal (e.g. a pipe in a script),
+	// and can always be overridden per-invocation with -no-input.
+	Interactive bool
+
+	// Trace enables stack-trace output for errors that carry one (see
+	// StackTracer). It can also be turned on per-invocation with -trace,
+	// or by setting CMDY_TRACE=1 in the environment.
+	Trace bool
+}
+
+// Run is a convenience wrapper around
+// (&Runner{Stdin: os.Stdin, Stdout: os.Stdout, Stderr: os.Stderr}).Run.
+func Run(ctx context.Context, argv []string, b Builder) error {
+	r := &Runner{Stdin: os.Stdin, Stdout: os.Stdout, Stderr: os.Stderr}
+	return r.Run(ctx, argv, b)
+}
+
+// Run builds a Command from b, configures its flags and args, parses
+// argv against them, and invokes its Run method.
+func (r *Runner) Run(ctx context.Context, argv []string, b Builder) error {
+	name := r.Name
+	if name == "" {
+		name = filepath.Base(os.Args[0])
+	}
+	return r.run(ctx, name, argv, b)
+}
+
+func (r *Runner) run(ctx context.Context, name string, argv []string, b Builder) error {
+	cmd := b()
+
+	flags := cmd.Flags()
+	if flags == nil {
+		flags = NewFlagSet()
+	}
+	args := cmd.Args()
+	if args == nil {
+		args = arg.NewArgSet()
+	}
+	cmd.Configure(flags, args)
+
+	var noInput bool
+	flags.BoolVar(&noInput, "no-input", false, "disable interactive argument prompts even when attached to a terminal")
+
+	trace := r.Trace || os.Getenv("CMDY_TRACE") == "1"
+	flags.BoolVar(&trace, "trace", trace, "print a stack trace alongside any error that carries one (env: CMDY_TRACE=1)")
+
+	usage := func() string { return buildUsage(name, cmd, flags, args) }
+
+	err := flags.Parse(argv)
+	Trace = trace
+	if err != nil {
+		return attachUsage(err, usage())
+	}
+
+	interactive := r.Interactive && !noInput && isTerminalReader(r.Stdin)
+	argErr := args.ParseContext(flags.Args(), arg.ParseOptions{
+		Interactive: interactive,
+		Stdin:       r.Stdin,
+		Stderr:      r.Stderr,
+	})
+	if argErr != nil {
+		return attachUsage(UsageError(argErr), usage())
+	}
+
+	rc := Context{
+		Context:     ctx,
+		Name:        name,
+		Stdin:       r.Stdin,
+		Stdout:      r.Stdout,
+		Stderr:      r.Stderr,
+		Interactive: r.Interactive && !noInput,
+	}
+
+	if err := cmd.Run(rc); err != nil {
+		if IsUsageError(err) {
+			return attachUsage(err, usage())
+		}
+		return err
+	}
+	return nil
+}
+
+// attachUsage populates a usageError's lazily-built usage text before it
+// is returned to the caller.
+func attachUsage(err error, usage string) error {
+	if u, ok := err.(*usageError); ok {
+		u.usage = usage
+	}
+	return err
+}
+
+func buildUsage(name string, cmd Command, flags *FlagSet, args *arg.ArgSet) string {
+	var b strings.Builder
+	if s := cmd.Synopsis(); s != "" {
+		b.WriteString(s)
+		b.WriteString("\n\n")
+	}
+	b.WriteString("Usage: " + name + " [options] " + args.Synopsis())
+	if body := strings.TrimSpace(cmd.Usage()); body != "" {
+		b.WriteString("\n\n")
+		b.WriteString(body)
+	}
+	return b.String()
+}
+
+// isTerminalReader reports whether r is a character device such as an
+// interactive terminal, as opposed to a pipe or redirected file.
+func isTerminalReader(r io.Reader) bool {
+	f, ok := r.(*os.File)
+	if !ok {
+		return false
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
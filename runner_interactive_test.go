@@ -0,0 +1,82 @@
+package cmdy
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	arg "github.com/ArtProcessors/cmdy/args"
+	"github.com/shabbyrobe/golib/assert"
+)
+
+// nonTerminalStdin wraps a reader so it never satisfies isTerminalReader,
+// matching how Stdin is piped in a test or script.
+type nonTerminalStdin struct{ r *strings.Reader }
+
+func (n *nonTerminalStdin) Read(p []byte) (int, error) { return n.r.Read(p) }
+
+func TestRunnerInteractiveDoesNotHangOnNonTerminalStdin(t *testing.T) {
+	tt := assert.WrapTB(t)
+
+	c := &testCmd{
+		configure: func(flags *FlagSet, args *arg.ArgSet) {
+			var name string
+			args.String(&name, "name", "usage...")
+		},
+	}
+
+	r := &Runner{
+		Stdin:       &nonTerminalStdin{r: strings.NewReader("")},
+		Stdout:      &bytes.Buffer{},
+		Stderr:      &bytes.Buffer{},
+		Interactive: true,
+	}
+
+	err := r.Run(context.Background(), nil, testBuilder(c))
+	tt.MustAssert(err != nil && strings.Contains(err.Error(), "missing arg <name>"), err)
+}
+
+func TestRunnerNoInputFlagDisablesInteractive(t *testing.T) {
+	tt := assert.WrapTB(t)
+
+	c := &testCmd{
+		configure: func(flags *FlagSet, args *arg.ArgSet) {
+			var name string
+			args.String(&name, "name", "usage...")
+		},
+	}
+
+	r := &Runner{
+		Stdin:       &bytes.Buffer{},
+		Stdout:      &bytes.Buffer{},
+		Stderr:      &bytes.Buffer{},
+		Interactive: true,
+	}
+
+	err := r.Run(context.Background(), []string{"-no-input"}, testBuilder(c))
+	tt.MustAssert(err != nil && strings.Contains(err.Error(), "missing arg <name>"), err)
+}
+
+func TestRunnerTraceDoesNotLeakBetweenRunners(t *testing.T) {
+	tt := assert.WrapTB(t)
+	defer func(old bool) { Trace = old }(Trace)
+
+	traced := &Runner{
+		Stdin:  &bytes.Buffer{},
+		Stdout: &bytes.Buffer{},
+		Stderr: &bytes.Buffer{},
+		Trace:  true,
+	}
+	tt.MustOK(traced.Run(context.Background(), nil, testCmdRunBuilder(func(c Context) error { return nil })))
+	tt.MustAssert(Trace, "expected Trace runner to leave Trace set to true")
+
+	untraced := &Runner{
+		Stdin:  &bytes.Buffer{},
+		Stdout: &bytes.Buffer{},
+		Stderr: &bytes.Buffer{},
+		Trace:  false,
+	}
+	tt.MustOK(untraced.Run(context.Background(), nil, testCmdRunBuilder(func(c Context) error { return nil })))
+	tt.MustAssert(!Trace, "expected a Runner with Trace=false to reset the package-level Trace")
+}